@@ -3,13 +3,42 @@ package completion
 import (
 	"github.com/codegangsta/cli"
 	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
+	"github.com/jfrog/jfrog-cli/artifactory"
 	"github.com/jfrog/jfrog-cli/completion/shells/bash"
+	"github.com/jfrog/jfrog-cli/completion/shells/fish"
+	"github.com/jfrog/jfrog-cli/completion/shells/powershell"
 	"github.com/jfrog/jfrog-cli/completion/shells/zsh"
+	"github.com/jfrog/jfrog-cli/distribution"
 	bash_docs "github.com/jfrog/jfrog-cli/docs/completion/bash"
+	fish_docs "github.com/jfrog/jfrog-cli/docs/completion/fish"
+	powershell_docs "github.com/jfrog/jfrog-cli/docs/completion/powershell"
 	zsh_docs "github.com/jfrog/jfrog-cli/docs/completion/zsh"
+	"github.com/jfrog/jfrog-cli/missioncontrol"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/xray"
 )
 
+// commandGroups lists every top-level command group and its subcommands, so the fish and
+// PowerShell completion scripts can cover the full command tree and flags. Every group but
+// "completion" itself delegates to that group's own GetCommands(), the same function the root
+// CLI app uses to register it, so this list can't drift from what's actually registered. The
+// "completion" entry is listed by name rather than by calling this package's own GetCommands(),
+// since that would recurse back into these very shell scripts.
+func commandGroups() map[string][]cli.Command {
+	return map[string][]cli.Command{
+		"rt": artifactory.GetCommands(),
+		"ds": distribution.GetCommands(),
+		"xr": xray.GetCommands(),
+		"mc": missioncontrol.GetCommands(),
+		"completion": {
+			{Name: "bash"},
+			{Name: "zsh"},
+			{Name: "fish"},
+			{Name: "powershell"},
+		},
+	}
+}
+
 func GetCommands() []cli.Command {
 	return cliutils.GetSortedCommands(cli.CommandsByName{
 		{
@@ -30,5 +59,23 @@ func GetCommands() []cli.Command {
 				zsh.WriteZshCompletionScript()
 			},
 		},
+		{
+			Name:         "fish",
+			Description:  fish_docs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("completion fish", fish_docs.GetDescription(), fish_docs.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(*cli.Context) {
+				fish.WriteFishCompletionScript(commandGroups())
+			},
+		},
+		{
+			Name:         "powershell",
+			Description:  powershell_docs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("completion powershell", powershell_docs.GetDescription(), powershell_docs.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(*cli.Context) {
+				powershell.WritePowerShellCompletionScript(commandGroups())
+			},
+		},
 	})
 }
@@ -0,0 +1,74 @@
+package fish
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/codegangsta/cli"
+)
+
+// WriteFishCompletionScript prints a fish completion script for the jfrog CLI to stdout.
+// Installed by sourcing the output, e.g. `jfrog completion fish | source`. commandGroups maps
+// each top-level command name (rt, xr, completion, ...) to its registered subcommands, so the
+// generated script always covers the full command tree and flags, not just a fixed snapshot.
+func WriteFishCompletionScript(commandGroups map[string][]cli.Command) {
+	fmt.Print(buildCompletionScript(commandGroups))
+}
+
+func buildCompletionScript(commandGroups map[string][]cli.Command) string {
+	var b strings.Builder
+	b.WriteString(preamble)
+
+	groups := sortedGroupNames(commandGroups)
+	for _, group := range groups {
+		b.WriteString(fmt.Sprintf("complete -c jfrog -n '__jfrog_needs_command' -a %s\n", group))
+	}
+	b.WriteString("\n")
+
+	for _, group := range groups {
+		for _, command := range commandGroups[group] {
+			b.WriteString(fmt.Sprintf("complete -c jfrog -n '__jfrog_using_command %s' -a %s -d %q\n", group, command.Name, command.Description))
+			for _, flag := range command.Flags {
+				b.WriteString(fmt.Sprintf("complete -c jfrog -n '__jfrog_using_command %s' -l %s\n", group, flagName(flag)))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func flagName(flag cli.Flag) string {
+	// A flag may be registered under several comma-separated aliases (e.g. "format, f");
+	// fish only needs the long form.
+	return strings.TrimSpace(strings.Split(flag.GetName(), ",")[0])
+}
+
+func sortedGroupNames(commandGroups map[string][]cli.Command) []string {
+	groups := make([]string, 0, len(commandGroups))
+	for group := range commandGroups {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+const preamble = `function __jfrog_needs_command
+    set -l cmd (commandline -opc)
+    if test (count $cmd) -eq 1
+        return 0
+    end
+    return 1
+end
+
+function __jfrog_using_command
+    set -l cmd (commandline -opc)
+    if test (count $cmd) -gt 1
+        if test $argv[1] = $cmd[2]
+            return 0
+        end
+    end
+    return 1
+end
+
+`
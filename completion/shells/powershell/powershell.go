@@ -0,0 +1,75 @@
+package powershell
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/codegangsta/cli"
+)
+
+// WritePowerShellCompletionScript prints a PowerShell completion script for the jfrog CLI to
+// stdout. Installed by adding `jfrog completion powershell | Out-String | Invoke-Expression` to
+// the profile. commandGroups maps each top-level command name (rt, xr, completion, ...) to its
+// registered subcommands, so the generated script always covers the full command tree and flags.
+func WritePowerShellCompletionScript(commandGroups map[string][]cli.Command) {
+	fmt.Print(buildCompletionScript(commandGroups))
+}
+
+func buildCompletionScript(commandGroups map[string][]cli.Command) string {
+	groups := sortedGroupNames(commandGroups)
+
+	var subcommandCases strings.Builder
+	for _, group := range groups {
+		var names, flags []string
+		for _, command := range commandGroups[group] {
+			names = append(names, "'"+command.Name+"'")
+			for _, flag := range command.Flags {
+				flags = append(flags, "'--"+flagName(flag)+"'")
+			}
+		}
+		subcommandCases.WriteString(fmt.Sprintf("        '%s' { @(%s) + @(%s) }\n", group, strings.Join(names, ", "), strings.Join(flags, ", ")))
+	}
+
+	var groupLiterals []string
+	for _, group := range groups {
+		groupLiterals = append(groupLiterals, "'"+group+"'")
+	}
+
+	return fmt.Sprintf(template, strings.Join(groupLiterals, ", "), subcommandCases.String())
+}
+
+func flagName(flag cli.Flag) string {
+	return strings.TrimSpace(strings.Split(flag.GetName(), ",")[0])
+}
+
+func sortedGroupNames(commandGroups map[string][]cli.Command) []string {
+	groups := make([]string, 0, len(commandGroups))
+	for group := range commandGroups {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+const template = `$jfrogCommandGroups = @(%s)
+
+Register-ArgumentCompleter -Native -CommandName jfrog -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $tokens = $commandAst.CommandElements | Select-Object -Skip 1 | ForEach-Object { $_.ToString() }
+
+    if ($tokens.Count -eq 0) {
+        $candidates = $jfrogCommandGroups
+    }
+    else {
+        $candidates = switch ($tokens[0]) {
+%s            default { @() }
+        }
+    }
+
+    $candidates | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}
+`
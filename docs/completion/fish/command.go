@@ -0,0 +1,7 @@
+package fish
+
+func GetDescription() string {
+	return "Generate fish completion script."
+}
+
+var Usage = []string{"jfrog completion fish"}
@@ -0,0 +1,7 @@
+package powershell
+
+func GetDescription() string {
+	return "Generate PowerShell completion script."
+}
+
+var Usage = []string{"jfrog completion powershell"}
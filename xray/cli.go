@@ -0,0 +1,150 @@
+package xray
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/codegangsta/cli"
+	xrutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/xray/formats"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// Supported --format values for audit/scan output.
+const (
+	formatJson  = "json"
+	formatSarif = "sarif"
+)
+
+// Supported --sbom values.
+const (
+	sbomCycloneDxJson = "cyclonedx-json"
+	sbomSpdxJson      = "spdx-json"
+)
+
+// GetCommands returns the xr (Xray) command group: audit-npm, audit-gradle, audit-mvn and scan.
+func GetCommands() []cli.Command {
+	return cliutils.GetSortedCommands(cli.CommandsByName{
+		newAuditCommand("audit-npm", "npm", "package.json"),
+		newAuditCommand("audit-gradle", "gradle", "build.gradle"),
+		newAuditCommand("audit-mvn", "maven", "pom.xml"),
+		newScanCommand(),
+	})
+}
+
+func newAuditCommand(name, projectType, descriptor string) cli.Command {
+	return cli.Command{
+		Name:  name,
+		Flags: auditFlags(),
+		Action: func(c *cli.Context) error {
+			// graphComponentIds is every dependency the audit path resolved into the graph that
+			// was handed to Xray for scanning, regardless of whether Xray flagged it - the same
+			// graph an SBOM needs to serialize in full.
+			responses, graphComponentIds, err := xrutils.RunGraphScan(projectType, c)
+			if err != nil {
+				return err
+			}
+			return runCommand(c, responses, graphComponentIds, descriptor)
+		},
+	}
+}
+
+func newScanCommand() cli.Command {
+	return cli.Command{
+		Name:  "scan",
+		Flags: auditFlags(),
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return fmt.Errorf("scan requires a file pattern argument")
+			}
+			binaryPath := c.Args().Get(0)
+			responses, graphComponentIds, err := xrutils.RunGraphScan("binary", c)
+			if err != nil {
+				return err
+			}
+			return runCommand(c, responses, graphComponentIds, binaryPath)
+		},
+	}
+}
+
+func auditFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{Name: "licenses", Usage: "Include license scan results in the output."},
+		cli.StringFlag{Name: "format", Value: formatJson, Usage: fmt.Sprintf("Output format: %s or %s.", formatJson, formatSarif)},
+		cli.StringFlag{Name: "sbom", Usage: fmt.Sprintf("Emit a software bill of materials instead of scan output: %s or %s.", sbomCycloneDxJson, sbomSpdxJson)},
+		cli.StringFlag{Name: "fail-on", Usage: "Exit non-zero when a vulnerability at or above this severity is found: low, medium, high or critical."},
+		cli.BoolFlag{Name: "fail-on-violations", Usage: "Exit non-zero when the scan returns any Xray watch violation."},
+	}
+}
+
+// runCommand writes the scan output and then, if --fail-on or --fail-on-violations is set,
+// evaluates the CI gate and turns a failing gate into a non-zero process exit.
+func runCommand(c *cli.Context, responses []services.ScanResponse, graphComponentIds []string, scannedPath string) error {
+	if err := writeOutput(c, responses, graphComponentIds, scannedPath); err != nil {
+		return err
+	}
+	return applyGate(c, responses)
+}
+
+// applyGate prints the policy gate's structured summary to stderr and returns a *cli.ExitError
+// (codegangsta/cli's mechanism for setting the process exit code) when the scan breaches the
+// configured --fail-on threshold or --fail-on-violations is set and violations were found.
+func applyGate(c *cli.Context, responses []services.ScanResponse) error {
+	failOnSeverity := c.String("fail-on")
+	if !formats.IsValidFailOnSeverity(failOnSeverity) {
+		return fmt.Errorf("unsupported --fail-on value %q, expected one of: low, medium, high, critical", failOnSeverity)
+	}
+	failOnViolations := c.Bool("fail-on-violations")
+	if failOnSeverity == "" && !failOnViolations {
+		return nil
+	}
+
+	gate := formats.EvaluateGate(responses, failOnSeverity, failOnViolations)
+	fmt.Fprintln(os.Stderr, gate.Summary)
+	if gate.ShouldFail {
+		return cli.NewExitError("", 1)
+	}
+	return nil
+}
+
+// writeOutput renders the scan responses according to --format and --sbom. --sbom takes
+// precedence: it emits a standards-compliant SBOM built from the same resolved dependency graph
+// instead of scan output. Otherwise "json" (the default) keeps the raw Xray scan response shape
+// that existing tooling already parses, and "sarif" converts the same responses into a SARIF
+// 2.1.0 report for GitHub Code Scanning and other SARIF consumers.
+func writeOutput(c *cli.Context, responses []services.ScanResponse, graphComponentIds []string, scannedPath string) error {
+	if sbom := c.String("sbom"); sbom != "" {
+		return writeSbom(sbom, responses, graphComponentIds, scannedPath)
+	}
+
+	switch format := c.String("format"); format {
+	case formatSarif:
+		return printJson(formats.ConvertScanResponseToSarif(responses, scannedPath))
+	case formatJson, "":
+		return printJson(responses)
+	default:
+		return fmt.Errorf("unsupported --format value %q, expected %q or %q", format, formatJson, formatSarif)
+	}
+}
+
+func writeSbom(sbom string, responses []services.ScanResponse, graphComponentIds []string, scannedPath string) error {
+	switch sbom {
+	case sbomCycloneDxJson:
+		return printJson(formats.ConvertScanResponseToCycloneDX(graphComponentIds, responses))
+	case sbomSpdxJson:
+		return printJson(formats.ConvertScanResponseToSPDX(graphComponentIds, responses, scannedPath))
+	default:
+		return fmt.Errorf("unsupported --sbom value %q, expected %q or %q", sbom, sbomCycloneDxJson, sbomSpdxJson)
+	}
+}
+
+func printJson(v interface{}) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(content))
+	return nil
+}
@@ -0,0 +1,75 @@
+package formats
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// severityRank orders Xray severities from lowest to highest, so a configured --fail-on threshold
+// also fails on every severity above it.
+var severityRank = map[string]int{
+	"Unknown":  0,
+	"Low":      1,
+	"Medium":   2,
+	"High":     3,
+	"Critical": 4,
+}
+
+// validFailOnSeverities are the only values --fail-on accepts, in lower case; "" disables the
+// severity threshold entirely.
+var validFailOnSeverities = map[string]bool{
+	"":         true,
+	"low":      true,
+	"medium":   true,
+	"high":     true,
+	"critical": true,
+}
+
+// GateResult is the outcome of evaluating scan responses against a --fail-on policy.
+type GateResult struct {
+	ShouldFail bool
+	Summary    string
+}
+
+// IsValidFailOnSeverity reports whether severity (case-insensitive) is a value --fail-on accepts:
+// "", "low", "medium", "high" or "critical".
+func IsValidFailOnSeverity(severity string) bool {
+	return validFailOnSeverities[strings.ToLower(strings.TrimSpace(severity))]
+}
+
+// EvaluateGate inspects the scan responses and decides whether the process should exit non-zero.
+// failOnSeverity is one of "critical", "high", "medium", "low", or "" to disable the severity
+// threshold; callers must reject anything else with IsValidFailOnSeverity before calling this,
+// since an unrecognized value would otherwise silently behave as "disabled" here. failOnViolations
+// fails the gate on any Xray watch violation regardless of severity.
+func EvaluateGate(responses []services.ScanResponse, failOnSeverity string, failOnViolations bool) GateResult {
+	threshold, hasThreshold := severityRank[strings.Title(strings.ToLower(strings.TrimSpace(failOnSeverity)))]
+	var failingVulnerabilities, failingViolations int
+
+	for _, response := range responses {
+		if failOnViolations {
+			failingViolations += len(response.Violations)
+		}
+		if hasThreshold {
+			for _, vulnerability := range response.Vulnerabilities {
+				if severityRank[vulnerability.Severity] >= threshold {
+					failingVulnerabilities++
+				}
+			}
+			for _, violation := range response.Violations {
+				if !failOnViolations && severityRank[violation.Severity] >= threshold {
+					failingViolations++
+				}
+			}
+		}
+	}
+
+	shouldFail := failingVulnerabilities > 0 || failingViolations > 0
+	summary := fmt.Sprintf(
+		"Xray scan policy gate: %d vulnerabilities at or above %q, %d violations triggered the gate.",
+		failingVulnerabilities, failOnSeverity, failingViolations,
+	)
+	return GateResult{ShouldFail: shouldFail, Summary: summary}
+}
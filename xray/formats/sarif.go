@@ -0,0 +1,212 @@
+package formats
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// Static SARIF 2.1.0 envelope fields. See https://docs.oasis-open.org/sarif/sarif/v2.1.0/
+const (
+	sarifSchema  = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "JFrog Xray"
+	toolInfoUri  = "https://jfrog.com/xray/"
+)
+
+// SarifReport is the root of a SARIF log file.
+type SarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	Id               string                 `json:"id"`
+	ShortDescription SarifMessage           `json:"shortDescription"`
+	FullDescription  SarifMessage           `json:"fullDescription"`
+	Properties       map[string]interface{} `json:"properties,omitempty"`
+}
+
+type SarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+}
+
+type SarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+// ConvertScanResponseToSarif converts Xray scan responses into a SARIF 2.1.0 report.
+// scannedFilePath is the dependency descriptor (package.json, pom.xml, build.gradle) or binary
+// path that was scanned, and is used as the location for every reported result.
+func ConvertScanResponseToSarif(responses []services.ScanResponse, scannedFilePath string) *SarifReport {
+	rules := map[string]SarifRule{}
+	var results []SarifResult
+	for _, response := range responses {
+		for _, vulnerability := range response.Vulnerabilities {
+			ruleId, rule, result := vulnerabilityToSarif(vulnerability, scannedFilePath)
+			rules[ruleId] = rule
+			results = append(results, result)
+		}
+		for _, violation := range response.Violations {
+			ruleId, rule, result := violationToSarif(violation, scannedFilePath)
+			rules[ruleId] = rule
+			results = append(results, result)
+		}
+	}
+
+	return &SarifReport{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []SarifRun{
+			{
+				Tool: SarifTool{
+					Driver: SarifDriver{
+						Name:           toolName,
+						InformationUri: toolInfoUri,
+						Rules:          sortedRules(rules),
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
+
+func vulnerabilityToSarif(vulnerability services.Vulnerability, scannedFilePath string) (string, SarifRule, SarifResult) {
+	ruleId := issueId(vulnerability.IssueId, vulnerability.Cves)
+	rule := SarifRule{
+		Id:               ruleId,
+		ShortDescription: SarifMessage{Text: ruleId},
+		FullDescription:  SarifMessage{Text: vulnerability.Summary},
+		Properties: map[string]interface{}{
+			"security-severity": vulnerability.Severity,
+			"cwe":               cwes(vulnerability.Cves),
+			"fixedVersions":     fixedVersions(vulnerability.Components),
+		},
+	}
+	result := SarifResult{
+		RuleId:    ruleId,
+		Level:     severityToSarifLevel(vulnerability.Severity),
+		Message:   SarifMessage{Text: vulnerability.Summary},
+		Locations: []SarifLocation{locationFor(scannedFilePath)},
+	}
+	return ruleId, rule, result
+}
+
+func violationToSarif(violation services.Violation, scannedFilePath string) (string, SarifRule, SarifResult) {
+	ruleId := issueId(violation.IssueId, violation.Cves)
+	rule := SarifRule{
+		Id:               ruleId,
+		ShortDescription: SarifMessage{Text: ruleId},
+		FullDescription:  SarifMessage{Text: violation.Summary},
+		Properties: map[string]interface{}{
+			"security-severity": violation.Severity,
+			"cwe":               cwes(violation.Cves),
+			"fixedVersions":     fixedVersions(violation.Components),
+			"watchName":         violation.WatchName,
+		},
+	}
+	result := SarifResult{
+		RuleId:    ruleId,
+		Level:     severityToSarifLevel(violation.Severity),
+		Message:   SarifMessage{Text: violation.Summary},
+		Locations: []SarifLocation{locationFor(scannedFilePath)},
+	}
+	return ruleId, rule, result
+}
+
+func issueId(issueId string, cves []services.Cve) string {
+	if len(cves) > 0 && cves[0].Id != "" {
+		return cves[0].Id
+	}
+	return issueId
+}
+
+func cwes(cves []services.Cve) []string {
+	var allCwes []string
+	for _, cve := range cves {
+		allCwes = append(allCwes, cve.Cwe...)
+	}
+	return allCwes
+}
+
+func fixedVersions(components map[string]services.Component) []string {
+	var allFixedVersions []string
+	for _, component := range components {
+		allFixedVersions = append(allFixedVersions, component.FixedVersions...)
+	}
+	return allFixedVersions
+}
+
+func locationFor(scannedFilePath string) SarifLocation {
+	return SarifLocation{
+		PhysicalLocation: SarifPhysicalLocation{
+			ArtifactLocation: SarifArtifactLocation{Uri: scannedFilePath},
+		},
+	}
+}
+
+// severityToSarifLevel maps Xray severities onto the SARIF result levels GitHub Code Scanning expects.
+func severityToSarifLevel(severity string) string {
+	switch severity {
+	case "Critical", "High":
+		return "error"
+	case "Medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func sortedRules(rules map[string]SarifRule) []SarifRule {
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	sorted := make([]SarifRule, 0, len(ids))
+	for _, id := range ids {
+		sorted = append(sorted, rules[id])
+	}
+	return sorted
+}
+
+// ResultCount returns the number of results in the first run of the report, for test assertions.
+func ResultCount(report *SarifReport) (int, error) {
+	if len(report.Runs) == 0 {
+		return 0, fmt.Errorf("sarif report contains no runs")
+	}
+	return len(report.Runs[0].Results), nil
+}
@@ -0,0 +1,209 @@
+package formats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+const (
+	cycloneDxSpecVersion = "1.4"
+	cycloneDxBomFormat   = "CycloneDX"
+	spdxVersion          = "SPDX-2.3"
+	spdxDataLicense      = "CC0-1.0"
+)
+
+// CycloneDxBom is a minimal CycloneDX 1.4 JSON BOM: https://cyclonedx.org/docs/1.4/json/
+type CycloneDxBom struct {
+	BomFormat       string                   `json:"bomFormat"`
+	SpecVersion     string                   `json:"specVersion"`
+	Components      []CycloneDxComponent     `json:"components"`
+	Vulnerabilities []CycloneDxVulnerability `json:"vulnerabilities,omitempty"`
+}
+
+type CycloneDxComponent struct {
+	Type     string                `json:"type"`
+	Name     string                `json:"name"`
+	Version  string                `json:"version"`
+	Purl     string                `json:"purl"`
+	Licenses []CycloneDxLicenseRef `json:"licenses,omitempty"`
+}
+
+type CycloneDxLicenseRef struct {
+	License CycloneDxLicense `json:"license"`
+}
+
+type CycloneDxLicense struct {
+	Id string `json:"id"`
+}
+
+type CycloneDxVulnerability struct {
+	Id      string                         `json:"id"`
+	Ratings []CycloneDxVulnerabilityRating `json:"ratings,omitempty"`
+	Affects []CycloneDxVulnerabilityTarget `json:"affects,omitempty"`
+}
+
+type CycloneDxVulnerabilityRating struct {
+	Severity string `json:"severity"`
+}
+
+type CycloneDxVulnerabilityTarget struct {
+	Ref string `json:"ref"`
+}
+
+// ConvertScanResponseToCycloneDX builds a CycloneDX 1.4 JSON SBOM from the full resolved
+// dependency graph (graphComponentIds, as already built by the audit path before it's handed to
+// Xray for scanning), overlaying license and, when available, vulnerability data from the scan
+// responses. Every resolved dependency is represented, not just the ones Xray flagged.
+func ConvertScanResponseToCycloneDX(graphComponentIds []string, responses []services.ScanResponse) *CycloneDxBom {
+	components := componentsFromGraph(graphComponentIds)
+	for _, response := range responses {
+		for _, license := range response.Licenses {
+			for componentId := range license.Components {
+				components[componentId] = mergeLicense(components[componentId], componentId, license.Name)
+			}
+		}
+		for _, vulnerability := range response.Vulnerabilities {
+			for componentId := range vulnerability.Components {
+				if _, exists := components[componentId]; !exists {
+					components[componentId] = componentFromId(componentId)
+				}
+			}
+		}
+	}
+
+	var vulnerabilities []CycloneDxVulnerability
+	for _, response := range responses {
+		for _, vulnerability := range response.Vulnerabilities {
+			vulnerabilities = append(vulnerabilities, CycloneDxVulnerability{
+				Id:      issueId(vulnerability.IssueId, vulnerability.Cves),
+				Ratings: []CycloneDxVulnerabilityRating{{Severity: vulnerability.Severity}},
+				Affects: affectedRefs(vulnerability.Components),
+			})
+		}
+	}
+
+	return &CycloneDxBom{
+		BomFormat:       cycloneDxBomFormat,
+		SpecVersion:     cycloneDxSpecVersion,
+		Components:      sortedComponents(components),
+		Vulnerabilities: vulnerabilities,
+	}
+}
+
+// SpdxDocument is a minimal SPDX 2.3 JSON document: https://spdx.github.io/spdx-spec/v2.3/
+type SpdxDocument struct {
+	SpdxVersion string        `json:"spdxVersion"`
+	DataLicense string        `json:"dataLicense"`
+	Name        string        `json:"name"`
+	Packages    []SpdxPackage `json:"packages"`
+}
+
+type SpdxPackage struct {
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo"`
+	ExternalRefs     []SpdxExternalRef `json:"externalRefs,omitempty"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+}
+
+type SpdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+// ConvertScanResponseToSPDX builds an SPDX 2.3 JSON SBOM from the full resolved dependency graph
+// (graphComponentIds), populating licenseConcluded from Xray's license scan results where
+// available and falling back to NOASSERTION for every other resolved dependency.
+func ConvertScanResponseToSPDX(graphComponentIds []string, responses []services.ScanResponse, documentName string) *SpdxDocument {
+	components := componentsFromGraph(graphComponentIds)
+	for _, response := range responses {
+		for _, license := range response.Licenses {
+			for componentId := range license.Components {
+				components[componentId] = mergeLicense(components[componentId], componentId, license.Name)
+			}
+		}
+	}
+
+	var packages []SpdxPackage
+	for _, component := range sortedComponents(components) {
+		licenseConcluded := "NOASSERTION"
+		if len(component.Licenses) > 0 {
+			licenseConcluded = component.Licenses[0].License.Id
+		}
+		packages = append(packages, SpdxPackage{
+			Name:        component.Name,
+			VersionInfo: component.Version,
+			ExternalRefs: []SpdxExternalRef{
+				{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: component.Purl},
+			},
+			LicenseConcluded: licenseConcluded,
+		})
+	}
+
+	return &SpdxDocument{
+		SpdxVersion: spdxVersion,
+		DataLicense: spdxDataLicense,
+		Name:        documentName,
+		Packages:    packages,
+	}
+}
+
+// componentsFromGraph seeds the component map from every dependency in the resolved graph, so
+// dependencies with no license or vulnerability findings still end up in the SBOM.
+func componentsFromGraph(graphComponentIds []string) map[string]CycloneDxComponent {
+	components := make(map[string]CycloneDxComponent, len(graphComponentIds))
+	for _, componentId := range graphComponentIds {
+		components[componentId] = componentFromId(componentId)
+	}
+	return components
+}
+
+func mergeLicense(existing CycloneDxComponent, componentId, licenseName string) CycloneDxComponent {
+	component := existing
+	if component.Name == "" {
+		component = componentFromId(componentId)
+	}
+	if licenseName != "" {
+		component.Licenses = []CycloneDxLicenseRef{{License: CycloneDxLicense{Id: licenseName}}}
+	}
+	return component
+}
+
+// componentFromId parses Xray's "name:version" component id into a CycloneDX component with a
+// best-effort generic PURL, since the package type isn't encoded in the id itself.
+func componentFromId(componentId string) CycloneDxComponent {
+	name, version := componentId, ""
+	if idx := strings.LastIndex(componentId, ":"); idx != -1 {
+		name, version = componentId[:idx], componentId[idx+1:]
+	}
+	return CycloneDxComponent{
+		Type:    "library",
+		Name:    name,
+		Version: version,
+		Purl:    fmt.Sprintf("pkg:generic/%s@%s", name, version),
+	}
+}
+
+func affectedRefs(components map[string]services.Component) []CycloneDxVulnerabilityTarget {
+	var refs []CycloneDxVulnerabilityTarget
+	for componentId := range components {
+		refs = append(refs, CycloneDxVulnerabilityTarget{Ref: componentFromId(componentId).Purl})
+	}
+	return refs
+}
+
+func sortedComponents(components map[string]CycloneDxComponent) []CycloneDxComponent {
+	ids := make([]string, 0, len(components))
+	for id := range components {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	sorted := make([]CycloneDxComponent, 0, len(ids))
+	for _, id := range ids {
+		sorted = append(sorted, components[id])
+	}
+	return sorted
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	xrutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
 	"github.com/jfrog/jfrog-cli/utils/tests"
+	"github.com/jfrog/jfrog-cli/xray/formats"
 	"github.com/jfrog/jfrog-client-go/auth"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
@@ -74,6 +75,14 @@ func TestXrayBinaryScan(t *testing.T) {
 	verifyScanResults(t, output, 0, 1, 1)
 }
 
+// Tests that a binary scan can be rendered as a SARIF 2.1.0 report for upload to GitHub Code Scanning.
+func TestXrayBinaryScanSarif(t *testing.T) {
+	initXrayTest(t, xrutils.GraphScanMinVersion)
+	binariesPath := filepath.Join(filepath.FromSlash(tests.GetTestResourcesPath()), "xray", "binaries", "*")
+	output := runAuditCmdWithOutput(t, "scan", binariesPath, "--licenses", "--format=sarif")
+	verifySarifResults(t, output, 1)
+}
+
 // Tests npm audit by providing simple npm project and asserts any error.
 func TestXrayAuditNpm(t *testing.T) {
 	initXrayTest(t, xrutils.GraphScanMinVersion)
@@ -92,6 +101,119 @@ func TestXrayAuditNpm(t *testing.T) {
 	verifyScanResults(t, output, 0, 1, 1)
 }
 
+// Tests that npm audit results can also be rendered as a SARIF 2.1.0 report.
+func TestXrayAuditNpmSarif(t *testing.T) {
+	initXrayTest(t, xrutils.GraphScanMinVersion)
+	tempDirPath, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer tests.RemoveTempDirAndAssert(t, tempDirPath)
+	npmProjectPath := filepath.Join(filepath.FromSlash(tests.GetTestResourcesPath()), "xray", "npm")
+	assert.NoError(t, fileutils.CopyDir(npmProjectPath, tempDirPath, true, nil))
+	prevWd := changeWD(t, tempDirPath)
+	defer tests.ChangeDirAndAssert(t, prevWd)
+	assert.NoError(t, exec.Command("npm", "install").Run())
+
+	output := runAuditCmdWithOutput(t, "audit-npm", "--licenses", "--format=sarif")
+	verifySarifResults(t, output, 1)
+}
+
+// Tests that npm audit results can also be rendered as a CycloneDX SBOM.
+func TestXrayAuditNpmSbom(t *testing.T) {
+	initXrayTest(t, xrutils.GraphScanMinVersion)
+	tempDirPath, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer tests.RemoveTempDirAndAssert(t, tempDirPath)
+	npmProjectPath := filepath.Join(filepath.FromSlash(tests.GetTestResourcesPath()), "xray", "npm")
+	assert.NoError(t, fileutils.CopyDir(npmProjectPath, tempDirPath, true, nil))
+	prevWd := changeWD(t, tempDirPath)
+	defer tests.ChangeDirAndAssert(t, prevWd)
+	assert.NoError(t, exec.Command("npm", "install").Run())
+
+	output := runAuditCmdWithOutput(t, "audit-npm", "--licenses", "--sbom=cyclonedx-json")
+	verifyCycloneDxResults(t, output, 1)
+}
+
+// Tests that maven audit results can also be rendered as an SPDX SBOM.
+func TestXrayAuditMavenSbom(t *testing.T) {
+	initXrayTest(t, xrutils.GraphScanMinVersion)
+	tempDirPath, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer tests.RemoveTempDirAndAssert(t, tempDirPath)
+	mvnProjectPath := filepath.Join(filepath.FromSlash(tests.GetTestResourcesPath()), "xray", "maven")
+	assert.NoError(t, fileutils.CopyDir(mvnProjectPath, tempDirPath, true, nil))
+	prevWd := changeWD(t, tempDirPath)
+	defer tests.ChangeDirAndAssert(t, prevWd)
+
+	output := runAuditCmdWithOutput(t, "audit-mvn", "--licenses", "--sbom=spdx-json")
+	verifySpdxResults(t, output, 1)
+}
+
+// Tests that --fail-on returns a non-zero exit code when the scan finds vulnerabilities at or
+// above the configured severity, and passes when the threshold is set above what was found.
+func TestXrayAuditNpmFailOnThreshold(t *testing.T) {
+	initXrayTest(t, xrutils.GraphScanMinVersion)
+	tempDirPath, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer tests.RemoveTempDirAndAssert(t, tempDirPath)
+	npmProjectPath := filepath.Join(filepath.FromSlash(tests.GetTestResourcesPath()), "xray", "npm")
+	assert.NoError(t, fileutils.CopyDir(npmProjectPath, tempDirPath, true, nil))
+	prevWd := changeWD(t, tempDirPath)
+	defer tests.ChangeDirAndAssert(t, prevWd)
+	assert.NoError(t, exec.Command("npm", "install").Run())
+
+	// The test npm project is known to resolve at least one low-severity vulnerability, so a
+	// "low" threshold should fail the gate.
+	assert.Error(t, runAuditCmdForExitCode(t, "audit-npm", "--licenses", "--format=json", "--fail-on=low"))
+
+	// The gate fails on findings at or above the threshold, so "critical" passes as long as the
+	// test npm project resolves no critical-severity finding.
+	assert.NoError(t, runAuditCmdForExitCode(t, "audit-npm", "--licenses", "--format=json", "--fail-on=critical"))
+}
+
+// Tests that --fail-on-violations fails the build whenever any Xray watch violation is returned,
+// independent of the --fail-on severity threshold.
+func TestXrayAuditMavenFailOnViolations(t *testing.T) {
+	initXrayTest(t, xrutils.GraphScanMinVersion)
+	tempDirPath, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer tests.RemoveTempDirAndAssert(t, tempDirPath)
+	mvnProjectPath := filepath.Join(filepath.FromSlash(tests.GetTestResourcesPath()), "xray", "maven")
+	assert.NoError(t, fileutils.CopyDir(mvnProjectPath, tempDirPath, true, nil))
+	prevWd := changeWD(t, tempDirPath)
+	defer tests.ChangeDirAndAssert(t, prevWd)
+
+	assert.Error(t, runAuditCmdForExitCode(t, "audit-mvn", "--licenses", "--format=json", "--fail-on-violations"))
+}
+
+// Tests that --fail-on-violations also gates gradle audits, covering that distinct project type.
+func TestXrayAuditGradleFailOnViolations(t *testing.T) {
+	initXrayTest(t, xrutils.GraphScanMinVersion)
+	tempDirPath, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer tests.RemoveTempDirAndAssert(t, tempDirPath)
+	gradleProjectPath := filepath.Join(filepath.FromSlash(tests.GetTestResourcesPath()), "xray", "gradle")
+	assert.NoError(t, fileutils.CopyDir(gradleProjectPath, tempDirPath, true, nil))
+	prevWd := changeWD(t, tempDirPath)
+	defer tests.ChangeDirAndAssert(t, prevWd)
+
+	assert.Error(t, runAuditCmdForExitCode(t, "audit-gradle", "--licenses", "--format=json", "--fail-on-violations"))
+}
+
+// Tests that --fail-on also gates the scan command, which goes through newScanCommand rather
+// than newAuditCommand.
+func TestXrayBinaryScanFailOnThreshold(t *testing.T) {
+	initXrayTest(t, xrutils.GraphScanMinVersion)
+	binariesPath := filepath.Join(filepath.FromSlash(tests.GetTestResourcesPath()), "xray", "binaries", "*")
+
+	// The test binaries are known to resolve at least one low-severity vulnerability, so a "low"
+	// threshold should fail the gate.
+	assert.Error(t, runAuditCmdForExitCode(t, "scan", binariesPath, "--licenses", "--format=json", "--fail-on=low"))
+
+	// The gate fails on findings at or above the threshold, so "critical" passes as long as the
+	// test binaries resolve no critical-severity finding.
+	assert.NoError(t, runAuditCmdForExitCode(t, "scan", binariesPath, "--licenses", "--format=json", "--fail-on=critical"))
+}
+
 func TestXrayAuditGradle(t *testing.T) {
 	initXrayTest(t, xrutils.GraphScanMinVersion)
 	tempDirPath, err := fileutils.CreateTempDir()
@@ -170,3 +292,44 @@ func verifyScanResults(t *testing.T, content string, minViolations, minVulnerabi
 	assert.True(t, len(results[0].Vulnerabilities) >= minVulnerabilities, fmt.Sprintf("Expected at least %d vulnerabilities in scan results, but got %d vulnerabilities.", minVulnerabilities, len(results[0].Vulnerabilities)))
 	assert.True(t, len(results[0].Licenses) >= minLicenses, fmt.Sprintf("Expected at least %d Licenses in scan results, but got %d Licenses.", minLicenses, len(results[0].Licenses)))
 }
+
+func verifySarifResults(t *testing.T, content string, minResults int) {
+	var report formats.SarifReport
+	assert.NoError(t, json.Unmarshal([]byte(content), &report))
+	resultCount, err := formats.ResultCount(&report)
+	assert.NoError(t, err)
+	assert.True(t, resultCount >= minResults, fmt.Sprintf("Expected at least %d results in the SARIF report, but got %d results.", minResults, resultCount))
+}
+
+// Run `jfrog` command and return the error instead of asserting on it, so callers can assert on
+// the exit code path wired through --fail-on/--fail-on-violations.
+func runAuditCmdForExitCode(t *testing.T, args ...string) error {
+	newStdout, stdWriter, previousStdout := tests.RedirectStdOutToPipe()
+	defer func() {
+		os.Stdout = previousStdout
+		newStdout.Close()
+	}()
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- xrayCli.Exec(args...)
+		stdWriter.Close()
+	}()
+	content, err := ioutil.ReadAll(newStdout)
+	assert.NoError(t, err)
+	previousStdout.Write(content)
+	return <-errChan
+}
+
+func verifyCycloneDxResults(t *testing.T, content string, minComponents int) {
+	var bom formats.CycloneDxBom
+	assert.NoError(t, json.Unmarshal([]byte(content), &bom))
+	assert.Equal(t, "CycloneDX", bom.BomFormat)
+	assert.True(t, len(bom.Components) >= minComponents, fmt.Sprintf("Expected at least %d components in the SBOM, but got %d components.", minComponents, len(bom.Components)))
+}
+
+func verifySpdxResults(t *testing.T, content string, minPackages int) {
+	var doc formats.SpdxDocument
+	assert.NoError(t, json.Unmarshal([]byte(content), &doc))
+	assert.Equal(t, "SPDX-2.3", doc.SpdxVersion)
+	assert.True(t, len(doc.Packages) >= minPackages, fmt.Sprintf("Expected at least %d packages in the SPDX document, but got %d packages.", minPackages, len(doc.Packages)))
+}